@@ -0,0 +1,71 @@
+// Package cards ingests a card catalog into the Card table and enforces
+// deck-construction legality rules against it.
+package cards
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+)
+
+func init() {
+	model.DeckValidator = ValidateDeck
+}
+
+// CatalogEntry is one card as it appears in an imported catalog.
+type CatalogEntry struct {
+	Name   string `json:"name"`
+	Cost   int    `json:"cost"`
+	Class  string `json:"class"`
+	Rarity string `json:"rarity"`
+	Set    string `json:"set"`
+}
+
+// ImportFromFile reads a JSON catalog file and upserts each entry into the
+// Card table, keyed by name. It returns how many entries were processed.
+func ImportFromFile(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var entries []CatalogEntry
+	if err := json.NewDecoder(f).Decode(&entries); err != nil {
+		return 0, fmt.Errorf("decode card catalog %s: %w", path, err)
+	}
+	return importEntries(entries)
+}
+
+// ImportFromURL fetches a JSON catalog from an HTTP endpoint and upserts
+// each entry into the Card table, keyed by name.
+func ImportFromURL(url string) (int, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("fetch card catalog %s: status %d", url, resp.StatusCode)
+	}
+
+	var entries []CatalogEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return 0, fmt.Errorf("decode card catalog %s: %w", url, err)
+	}
+	return importEntries(entries)
+}
+
+func importEntries(entries []CatalogEntry) (int, error) {
+	for _, e := range entries {
+		card := model.Card{Name: e.Name, Cost: e.Cost, Class: e.Class, Rarity: e.Rarity, Set: e.Set}
+		err := model.DB.Where(model.Card{Name: e.Name}).Assign(card).FirstOrCreate(&card).Error
+		if err != nil {
+			return 0, fmt.Errorf("import card %q: %w", e.Name, err)
+		}
+	}
+	return len(entries), nil
+}
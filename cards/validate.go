@@ -0,0 +1,60 @@
+package cards
+
+import (
+	"fmt"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+)
+
+const (
+	deckSize              = 30
+	maxCopiesNonLegendary = 2
+	maxCopiesLegendary    = 1
+	legendaryRarity       = "Legendary"
+	neutralClass          = "Neutral"
+)
+
+// ValidateDeck enforces Hearthstone's deck-construction rules: exactly 30
+// cards, at most 2 copies of any non-legendary card and 1 of any
+// legendary, and every card must match the owning hero's class or be
+// Neutral.
+func ValidateDeck(deck *model.Deck) error {
+	var hero model.Hero
+	if err := model.DB.First(&hero, deck.HeroID).Error; err != nil {
+		return fmt.Errorf("deck %q: owning hero not found: %w", deck.Name, err)
+	}
+	return validateDeckForHero(deck, hero)
+}
+
+// validateDeckForHero is the actual rule check, split out from ValidateDeck
+// so it can be exercised without a live database in tests.
+func validateDeckForHero(deck *model.Deck, hero model.Hero) error {
+	total := 0
+	for _, dc := range deck.Cards {
+		card := dc.Card
+		if card.ID == 0 && dc.CardID != 0 {
+			if err := model.DB.First(&card, dc.CardID).Error; err != nil {
+				return fmt.Errorf("deck %q: card %d not found: %w", deck.Name, dc.CardID, err)
+			}
+		}
+
+		if card.Class != neutralClass && card.Class != hero.Name {
+			return fmt.Errorf("deck %q: %q is a %s card, not playable by %s", deck.Name, card.Name, card.Class, hero.Name)
+		}
+
+		max := maxCopiesNonLegendary
+		if card.Rarity == legendaryRarity {
+			max = maxCopiesLegendary
+		}
+		if dc.Quantity > max {
+			return fmt.Errorf("deck %q: %d copies of %q exceeds the limit of %d", deck.Name, dc.Quantity, card.Name, max)
+		}
+
+		total += dc.Quantity
+	}
+
+	if total != deckSize {
+		return fmt.Errorf("deck %q: has %d cards, must have exactly %d", deck.Name, total, deckSize)
+	}
+	return nil
+}
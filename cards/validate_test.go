@@ -0,0 +1,69 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+)
+
+func fillerCards(n int) []model.DeckCard {
+	cards := make([]model.DeckCard, n)
+	for i := range cards {
+		cards[i] = model.DeckCard{
+			Card:     model.Card{Name: "Filler", Class: neutralClass, Rarity: "Common"},
+			Quantity: 1,
+		}
+	}
+	return cards
+}
+
+func TestValidateDeckRejectsWrongCardCount(t *testing.T) {
+	hero := model.Hero{Name: "Mage"}
+	deck := &model.Deck{Name: "Too Few", HeroID: hero.ID, Cards: fillerCards(29)}
+
+	if err := validateDeckForHero(deck, hero); err == nil {
+		t.Fatal("expected an error for a 29-card deck")
+	}
+}
+
+func TestValidateDeckRejectsTooManyLegendaryCopies(t *testing.T) {
+	hero := model.Hero{Name: "Mage"}
+	cards := fillerCards(28)
+	cards = append(cards, model.DeckCard{
+		Card:     model.Card{Name: "Ragnaros", Class: neutralClass, Rarity: legendaryRarity},
+		Quantity: 2,
+	})
+	deck := &model.Deck{Name: "Double Legendary", HeroID: hero.ID, Cards: cards}
+
+	if err := validateDeckForHero(deck, hero); err == nil {
+		t.Fatal("expected an error for 2 copies of a legendary card")
+	}
+}
+
+func TestValidateDeckRejectsWrongClassCard(t *testing.T) {
+	hero := model.Hero{Name: "Mage"}
+	cards := fillerCards(29)
+	cards = append(cards, model.DeckCard{
+		Card:     model.Card{Name: "Execute", Class: "Warrior", Rarity: "Common"},
+		Quantity: 1,
+	})
+	deck := &model.Deck{Name: "Off Class", HeroID: hero.ID, Cards: cards}
+
+	if err := validateDeckForHero(deck, hero); err == nil {
+		t.Fatal("expected an error for a card outside the hero's class")
+	}
+}
+
+func TestValidateDeckAcceptsLegalDeck(t *testing.T) {
+	hero := model.Hero{Name: "Mage"}
+	cards := fillerCards(28)
+	cards = append(cards,
+		model.DeckCard{Card: model.Card{Name: "Fireball", Class: "Mage", Rarity: "Common"}, Quantity: 1},
+		model.DeckCard{Card: model.Card{Name: "Ragnaros", Class: neutralClass, Rarity: legendaryRarity}, Quantity: 1},
+	)
+	deck := &model.Deck{Name: "Legal Deck", HeroID: hero.ID, Cards: cards}
+
+	if err := validateDeckForHero(deck, hero); err != nil {
+		t.Fatalf("unexpected error for a legal deck: %v", err)
+	}
+}
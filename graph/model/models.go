@@ -0,0 +1,20 @@
+// Package model holds GraphQL-facing types that have no corresponding
+// GORM entity in the domain model package. Types that do (User, Hero, ...)
+// are bound directly via gqlgen's autobind instead of being duplicated
+// here.
+package model
+
+// Match is the payload pushed by the matchFound subscription; OpponentID
+// and RoomToken are resolved relative to the subscribing user.
+type Match struct {
+	ID         int
+	OpponentID int
+	RoomToken  string
+}
+
+// PoolStatus is a point-in-time snapshot of a single matching bracket.
+type PoolStatus struct {
+	MinPt       int
+	MaxPt       int
+	QueueLength int
+}
@@ -0,0 +1,157 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any
+// resolver implementations will be copied through when generating and any
+// unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.45
+
+import (
+	"context"
+	"fmt"
+
+	graphmodel "github.com/NarwhalChen/Hearthstone-ELO-Matching-System/graph/model"
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/matching"
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/provider"
+)
+
+// Resolver is the root DI struct gqlgen's generated code embeds into every
+// resolver. Most shared state (the DB handle, matching pools) lives in
+// package globals following the rest of the codebase; RatingProvider is
+// injected here instead since operators may swap it per deployment.
+type Resolver struct {
+	RatingProvider provider.RatingProvider
+}
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, name string, externalID *string) (*model.User, error) {
+	id := ""
+	if externalID != nil {
+		id = *externalID
+	}
+	user := model.CreateUser(name, id, r.RatingProvider)
+	return &user, nil
+}
+
+// SelectHero is the resolver for the selectHero field.
+func (r *mutationResolver) SelectHero(ctx context.Context, userID int, heroID int) (*model.User, error) {
+	var user model.User
+	if err := model.DB.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	user.CurHeroID = heroID
+	model.DB.Save(&user)
+	return &user, nil
+}
+
+// EnqueueForMatch is the resolver for the enqueueForMatch field.
+func (r *mutationResolver) EnqueueForMatch(ctx context.Context, userID int) (bool, error) {
+	var user model.User
+	if err := model.DB.First(&user, userID).Error; err != nil {
+		return false, err
+	}
+	if !matching.EnqueueForMatch(&user) {
+		return false, fmt.Errorf("no matching pool covers user %d's current Elo", userID)
+	}
+	return true, nil
+}
+
+// LeaveQueue is the resolver for the leaveQueue field.
+func (r *mutationResolver) LeaveQueue(ctx context.Context, userID int) (bool, error) {
+	return matching.LeaveQueue(userID), nil
+}
+
+// ReportMatchResult is the resolver for the reportMatchResult field.
+func (r *mutationResolver) ReportMatchResult(ctx context.Context, matchID int, result int) (bool, error) {
+	if _, err := model.ReportMatchResult(matchID, result); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context, userID int) (*model.User, error) {
+	var user model.User
+	if err := model.DB.First(&user, userID).Error; err != nil {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+// Hero is the resolver for the hero field.
+func (r *queryResolver) Hero(ctx context.Context, id int) (*model.Hero, error) {
+	var hero model.Hero
+	if err := model.DB.First(&hero, id).Error; err != nil {
+		return nil, nil
+	}
+	return &hero, nil
+}
+
+// LeaderboardByHero is the resolver for the leaderboardByHero field.
+func (r *queryResolver) LeaderboardByHero(ctx context.Context, heroName string, limit int) ([]*model.Hero, error) {
+	heroes, err := model.LeaderboardByHero(heroName, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Hero, len(heroes))
+	for i := range heroes {
+		result[i] = &heroes[i]
+	}
+	return result, nil
+}
+
+// PoolStatus is the resolver for the poolStatus field.
+func (r *queryResolver) PoolStatus(ctx context.Context) ([]*graphmodel.PoolStatus, error) {
+	status := matching.Status()
+	result := make([]*graphmodel.PoolStatus, len(status))
+	for i, s := range status {
+		result[i] = &graphmodel.PoolStatus{MinPt: s.MinPt, MaxPt: s.MaxPt, QueueLength: s.QueueLength}
+	}
+	return result, nil
+}
+
+// MatchFound is the resolver for the matchFound field.
+func (r *subscriptionResolver) MatchFound(ctx context.Context, userID int) (<-chan *graphmodel.Match, error) {
+	events := matching.Events.Subscribe(userID)
+	out := make(chan *graphmodel.Match, 1)
+
+	go func() {
+		defer close(out)
+		defer matching.Events.Unsubscribe(userID)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				out <- &graphmodel.Match{ID: ev.MatchID, OpponentID: ev.OpponentID, RoomToken: ev.RoomToken}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// CurWinRate is the resolver for the curWinRate field.
+func (r *heroResolver) CurWinRate(ctx context.Context, obj *model.Hero) (float64, error) {
+	return float64(obj.CurWinRate), nil
+}
+
+// Hero returns HeroResolver implementation.
+func (r *Resolver) Hero() HeroResolver { return &heroResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type heroResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,27 @@
+package matching
+
+import "context"
+
+// PoolBackend stores and pops a single matching bracket's queue. It is the
+// seam that lets MatchingPool.StartMatching run as N stateless replicas:
+// InMemoryBackend keeps the original process-local slice behavior, and
+// RedisBackend shares one queue across every replica behind a load
+// balancer.
+type PoolBackend interface {
+	// Enqueue adds userID to bracket's queue at the given score (hero Elo).
+	Enqueue(ctx context.Context, bracket string, userID int, score float64) error
+	// Dequeue removes userID from bracket's queue, e.g. on LeaveQueue.
+	Dequeue(ctx context.Context, bracket string, userID int) error
+	// Peek returns the id of whichever user is currently at the front of
+	// bracket's queue, ordered by score.
+	Peek(ctx context.Context, bracket string) (userID int, ok bool, err error)
+	// GrowAllowedDiff widens by delta the allowed search diff remembered
+	// for userID and returns the new total, so the matchmaker's search
+	// window keeps growing the longer a user waits even across replicas.
+	GrowAllowedDiff(ctx context.Context, bracket string, userID int, delta float64) (allowedDiff float64, err error)
+	// PopPair atomically finds and removes two queued users whose scores
+	// differ by at most allowedDiff, handing them to exactly one caller.
+	PopPair(ctx context.Context, bracket string, allowedDiff float64) (userAID int, userBID int, ok bool, err error)
+	// Len reports how many users are currently queued in bracket.
+	Len(ctx context.Context, bracket string) (int, error)
+}
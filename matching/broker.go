@@ -0,0 +1,88 @@
+package matching
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+)
+
+// MatchFoundEvent is published to both participants once the matchmaker
+// pairs them; RoomToken is shared, OpponentID is perspective-specific.
+type MatchFoundEvent struct {
+	MatchID    int
+	UserID     int
+	OpponentID int
+	RoomToken  string
+}
+
+// Broker fans MatchFoundEvents out to per-user subscriber channels, keyed
+// by userID, so the GraphQL subscription layer can listen for just its
+// caller's match without scanning every pairing.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[int]chan MatchFoundEvent
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]chan MatchFoundEvent)}
+}
+
+// Subscribe registers a buffered channel for userID, replacing any previous
+// subscription for that user.
+func (b *Broker) Subscribe(userID int) chan MatchFoundEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan MatchFoundEvent, 1)
+	b.subscribers[userID] = ch
+	return ch
+}
+
+// Unsubscribe closes and removes userID's channel, if any.
+func (b *Broker) Unsubscribe(userID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.subscribers[userID]; ok {
+		close(ch)
+		delete(b.subscribers, userID)
+	}
+}
+
+// Publish delivers event to userID's subscriber, if one is listening. The
+// send is non-blocking: a subscriber stalled on delivering a previous
+// event (e.g. a slow GraphQL transport) would otherwise hold up this
+// Publish call while still holding b.mu, freezing Subscribe/Unsubscribe/
+// Publish for every other user.
+func (b *Broker) Publish(userID int, event MatchFoundEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.subscribers[userID]; ok {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Events is the process-wide broker used by startMatching to announce new
+// pairings to the GraphQL subscription resolvers.
+var Events = NewBroker()
+
+// gameRoom pairs two queued users into a pending Match and publishes a
+// MatchFound event to each; the Elo update itself is deferred until
+// ReportMatchResult commits a result.
+func gameRoom(user1 model.User, user2 model.User) {
+	token := newRoomToken()
+	match := model.Match{User1ID: user1.ID, User2ID: user2.ID, RoomToken: token}
+	model.DB.Create(&match)
+
+	Events.Publish(user1.ID, MatchFoundEvent{MatchID: match.ID, UserID: user1.ID, OpponentID: user2.ID, RoomToken: token})
+	Events.Publish(user2.ID, MatchFoundEvent{MatchID: match.ID, UserID: user2.ID, OpponentID: user1.ID, RoomToken: token})
+}
+
+func newRoomToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
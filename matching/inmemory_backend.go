@@ -0,0 +1,108 @@
+package matching
+
+import (
+	"context"
+	"sync"
+)
+
+type queueEntry struct {
+	userID int
+	score  float64
+}
+
+// InMemoryBackend is a PoolBackend that keeps each bracket's queue in a
+// process-local slice; it is the default and preserves the matchmaker's
+// original single-instance behavior.
+type InMemoryBackend struct {
+	mu      sync.Mutex
+	queues  map[string][]queueEntry
+	allowed map[string]map[int]float64
+}
+
+func NewInMemoryBackend() *InMemoryBackend {
+	return &InMemoryBackend{
+		queues:  make(map[string][]queueEntry),
+		allowed: make(map[string]map[int]float64),
+	}
+}
+
+func (b *InMemoryBackend) Enqueue(ctx context.Context, bracket string, userID int, score float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queues[bracket] = append(b.queues[bracket], queueEntry{userID: userID, score: score})
+	return nil
+}
+
+func (b *InMemoryBackend) Dequeue(ctx context.Context, bracket string, userID int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(bracket, userID)
+	return nil
+}
+
+func (b *InMemoryBackend) remove(bracket string, userID int) {
+	queue := b.queues[bracket]
+	for i, e := range queue {
+		if e.userID == userID {
+			b.queues[bracket] = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	delete(b.allowed[bracket], userID)
+}
+
+func (b *InMemoryBackend) Peek(ctx context.Context, bracket string) (int, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	queue := b.queues[bracket]
+	if len(queue) == 0 {
+		return 0, false, nil
+	}
+	return queue[0].userID, true, nil
+}
+
+func (b *InMemoryBackend) GrowAllowedDiff(ctx context.Context, bracket string, userID int, delta float64) (float64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.allowed[bracket] == nil {
+		b.allowed[bracket] = make(map[int]float64)
+	}
+	b.allowed[bracket][userID] += delta
+	return b.allowed[bracket][userID], nil
+}
+
+func (b *InMemoryBackend) PopPair(ctx context.Context, bracket string, allowedDiff float64) (int, int, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	queue := b.queues[bracket]
+	if len(queue) < 2 {
+		return 0, 0, false, nil
+	}
+
+	first := queue[0]
+	for i := 1; i < len(queue); i++ {
+		if abs64(first.score-queue[i].score) <= allowedDiff {
+			second := queue[i]
+			remaining := append([]queueEntry{}, queue[1:i]...)
+			remaining = append(remaining, queue[i+1:]...)
+			b.queues[bracket] = remaining
+			delete(b.allowed[bracket], first.userID)
+			delete(b.allowed[bracket], second.userID)
+			return first.userID, second.userID, true, nil
+		}
+	}
+	return 0, 0, false, nil
+}
+
+func (b *InMemoryBackend) Len(ctx context.Context, bracket string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.queues[bracket]), nil
+}
+
+func abs64(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
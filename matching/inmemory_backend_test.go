@@ -0,0 +1,42 @@
+package matching
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInMemoryBackendPopPairWithinAllowedDiff(t *testing.T) {
+	ctx := context.Background()
+	backend := NewInMemoryBackend()
+
+	backend.Enqueue(ctx, "1000-1200", 1, 1100)
+	backend.Enqueue(ctx, "1000-1200", 2, 1150)
+	backend.Enqueue(ctx, "1000-1200", 3, 1190)
+
+	userA, userB, ok, err := backend.PopPair(ctx, "1000-1200", 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || userA != 1 || userB != 2 {
+		t.Fatalf("got (%d, %d, %v), want (1, 2, true)", userA, userB, ok)
+	}
+
+	if n, _ := backend.Len(ctx, "1000-1200"); n != 1 {
+		t.Fatalf("Len = %d, want 1 (user 3 left behind)", n)
+	}
+}
+
+func TestInMemoryBackendDequeueRemovesAllowedDiffState(t *testing.T) {
+	ctx := context.Background()
+	backend := NewInMemoryBackend()
+
+	backend.Enqueue(ctx, "1000-1200", 1, 1100)
+	backend.GrowAllowedDiff(ctx, "1000-1200", 1, 50)
+
+	if err := backend.Dequeue(ctx, "1000-1200", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := backend.Len(ctx, "1000-1200"); n != 0 {
+		t.Fatalf("Len = %d, want 0 after dequeue", n)
+	}
+}
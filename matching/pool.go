@@ -0,0 +1,143 @@
+// Package matching holds the matchmaking pools: brackets of waiting users
+// searched by a background goroutine per pool. The queue itself lives
+// behind a PoolBackend, so the same StartMatching loop runs correctly
+// whether it's the only matcher or one of several replicas sharing Redis.
+package matching
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/model"
+)
+
+// tickInterval is how often StartMatching polls its bracket. RedisBackend
+// also uses it to rate-limit GrowAllowedDiff so that N replicas ticking
+// concurrently widen the search window at the same rate as a single
+// instance would, instead of N times as fast.
+const tickInterval = 1 * time.Second
+
+type MatchingPool struct {
+	bracket string
+	minPt   int
+	maxPt   int
+	backend PoolBackend
+}
+
+var Pools []MatchingPool
+
+// Backend is shared by every pool; it defaults to an in-process queue and
+// can be swapped for a RedisBackend in main before InitMatchingPools runs.
+var Backend PoolBackend = NewInMemoryBackend()
+
+// InitMatchingPools initializes the matching pools over the current Backend.
+func InitMatchingPools() {
+	Pools = []MatchingPool{
+		newPool(1000, 1200),
+		newPool(1201, 1400),
+		newPool(1401, 1600),
+	}
+}
+
+func newPool(minPt, maxPt int) MatchingPool {
+	return MatchingPool{
+		bracket: fmt.Sprintf("%d-%d", minPt, maxPt),
+		minPt:   minPt,
+		maxPt:   maxPt,
+		backend: Backend,
+	}
+}
+
+// AddUserToPoll adds a user to a matching pool.
+func AddUserToPoll(curUser *model.User, pool *MatchingPool) {
+	curUser.AllowedDiff = 0
+	pool.backend.Enqueue(context.Background(), pool.bracket, curUser.ID, float64(curUser.GetCurHeroPt()))
+}
+
+// EnqueueForMatch places a user into the bracket matching their current
+// hero Elo. It reports false if no bracket covers that rating.
+func EnqueueForMatch(user *model.User) bool {
+	pt := user.GetCurHeroPt()
+	for i := range Pools {
+		if pt >= Pools[i].minPt && pt <= Pools[i].maxPt {
+			AddUserToPoll(user, &Pools[i])
+			return true
+		}
+	}
+	return false
+}
+
+// LeaveQueue removes a user from whichever pool currently holds them. It
+// reports false if the user was not queued in any pool.
+func LeaveQueue(userID int) bool {
+	ctx := context.Background()
+	for i := range Pools {
+		n, err := Pools[i].backend.Len(ctx, Pools[i].bracket)
+		if err != nil || n == 0 {
+			continue
+		}
+		if err := Pools[i].backend.Dequeue(ctx, Pools[i].bracket, userID); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolStatus is a point-in-time snapshot of a single bracket's queue.
+type PoolStatus struct {
+	MinPt       int
+	MaxPt       int
+	QueueLength int
+}
+
+// Status reports a snapshot of every pool's queue length.
+func Status() []PoolStatus {
+	ctx := context.Background()
+	status := make([]PoolStatus, len(Pools))
+	for i := range Pools {
+		n, _ := Pools[i].backend.Len(ctx, Pools[i].bracket)
+		status[i] = PoolStatus{MinPt: Pools[i].minPt, MaxPt: Pools[i].maxPt, QueueLength: n}
+	}
+	return status
+}
+
+// StartMatching runs the matching loop for the pool. It is stateless
+// beyond its PoolBackend, so it is safe to run concurrently across many
+// replicas sharing a RedisBackend.
+func (curPool *MatchingPool) StartMatching() {
+	for {
+		curPool.tick(context.Background())
+		time.Sleep(tickInterval)
+	}
+}
+
+func (curPool *MatchingPool) tick(ctx context.Context) {
+	n, err := curPool.backend.Len(ctx, curPool.bracket)
+	if err != nil || n < 2 {
+		return
+	}
+
+	frontID, ok, err := curPool.backend.Peek(ctx, curPool.bracket)
+	if err != nil || !ok {
+		return
+	}
+
+	var frontUser model.User
+	model.DB.First(&frontUser, frontID)
+
+	allowedDiff, err := curPool.backend.GrowAllowedDiff(ctx, curPool.bracket, frontID, frontUser.SearchWindow())
+	if err != nil {
+		return
+	}
+
+	userAID, userBID, ok, err := curPool.backend.PopPair(ctx, curPool.bracket, allowedDiff)
+	if err != nil || !ok {
+		return
+	}
+
+	var userA, userB model.User
+	model.DB.First(&userA, userAID)
+	model.DB.First(&userB, userBID)
+	go gameRoom(userA, userB)
+}
@@ -0,0 +1,160 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend is a PoolBackend backed by a Redis sorted set per bracket
+// (member = userID, score = current hero Elo), so any number of
+// matchmaker replicas can share one queue behind a load balancer.
+type RedisBackend struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBackend builds a RedisBackend; all of its keys are namespaced
+// under "pool:" so it can share a Redis instance with unrelated data.
+func NewRedisBackend(client *redis.Client) *RedisBackend {
+	return &RedisBackend{client: client, prefix: "pool"}
+}
+
+func (b *RedisBackend) queueKey(bracket string) string {
+	return fmt.Sprintf("%s:%s:queue", b.prefix, bracket)
+}
+
+func (b *RedisBackend) allowedKey(bracket string) string {
+	return fmt.Sprintf("%s:%s:allowed", b.prefix, bracket)
+}
+
+func (b *RedisBackend) grownAtKey(bracket string) string {
+	return fmt.Sprintf("%s:%s:grown_at", b.prefix, bracket)
+}
+
+func (b *RedisBackend) Enqueue(ctx context.Context, bracket string, userID int, score float64) error {
+	return b.client.ZAdd(ctx, b.queueKey(bracket), redis.Z{Score: score, Member: userID}).Err()
+}
+
+func (b *RedisBackend) Dequeue(ctx context.Context, bracket string, userID int) error {
+	pipe := b.client.TxPipeline()
+	pipe.ZRem(ctx, b.queueKey(bracket), userID)
+	pipe.HDel(ctx, b.allowedKey(bracket), strconv.Itoa(userID))
+	pipe.HDel(ctx, b.grownAtKey(bracket), strconv.Itoa(userID))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (b *RedisBackend) Peek(ctx context.Context, bracket string) (int, bool, error) {
+	members, err := b.client.ZRange(ctx, b.queueKey(bracket), 0, 0).Result()
+	if err != nil {
+		return 0, false, err
+	}
+	if len(members) == 0 {
+		return 0, false, nil
+	}
+	id, err := strconv.Atoi(members[0])
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// growAllowedDiffScript grows the remembered allowed-diff for userID by
+// delta, but only if at least tickInterval has elapsed since the last
+// grow. Without this guard, N matchmaker replicas all ticking once a
+// second against the same bracket would each call GrowAllowedDiff
+// independently and widen the search window N times as fast as a single
+// instance would. ARGV: userID, delta, now (unix seconds), min interval
+// seconds. KEYS[1] = allowed-diff hash, KEYS[2] = last-grown-at hash.
+var growAllowedDiffScript = redis.NewScript(`
+local id = ARGV[1]
+local delta = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local minInterval = tonumber(ARGV[4])
+local last = redis.call('HGET', KEYS[2], id)
+if last == false or (now - tonumber(last)) >= minInterval then
+  redis.call('HSET', KEYS[2], id, now)
+  return redis.call('HINCRBYFLOAT', KEYS[1], id, delta)
+end
+local cur = redis.call('HGET', KEYS[1], id)
+if cur == false then
+  return '0'
+end
+return cur
+`)
+
+func (b *RedisBackend) GrowAllowedDiff(ctx context.Context, bracket string, userID int, delta float64) (float64, error) {
+	now := float64(time.Now().UnixNano()) / 1e9
+	res, err := growAllowedDiffScript.Run(ctx, b.client,
+		[]string{b.allowedKey(bracket), b.grownAtKey(bracket)},
+		userID, delta, now, tickInterval.Seconds(),
+	).Result()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(fmt.Sprint(res), 64)
+}
+
+// popPairScript atomically pops the two lowest-score members of the queue
+// whose scores are within ARGV[1] of each other, matching the
+// first-user-vs-rest scan the original in-process matchmaker used.
+// KEYS[1] = queue sorted set, KEYS[2] = allowed-diff hash, KEYS[3] =
+// last-grown-at hash, ARGV[1] = allowedDiff.
+var popPairScript = redis.NewScript(`
+local allowed = tonumber(ARGV[1])
+local members = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+if #members < 4 then
+  return nil
+end
+local idA, scoreA = members[1], tonumber(members[2])
+for j = 3, #members - 1, 2 do
+  local idB, scoreB = members[j], tonumber(members[j + 1])
+  if math.abs(scoreA - scoreB) <= allowed then
+    redis.call('ZREM', KEYS[1], idA, idB)
+    redis.call('HDEL', KEYS[2], idA, idB)
+    redis.call('HDEL', KEYS[3], idA, idB)
+    return {idA, idB}
+  end
+end
+return nil
+`)
+
+func (b *RedisBackend) PopPair(ctx context.Context, bracket string, allowedDiff float64) (int, int, bool, error) {
+	res, err := popPairScript.Run(ctx, b.client, []string{b.queueKey(bracket), b.allowedKey(bracket), b.grownAtKey(bracket)}, allowedDiff).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+	if res == nil {
+		return 0, 0, false, nil
+	}
+
+	pair, ok := res.([]interface{})
+	if !ok || len(pair) != 2 {
+		return 0, 0, false, nil
+	}
+	idA, errA := strconv.Atoi(fmt.Sprint(pair[0]))
+	idB, errB := strconv.Atoi(fmt.Sprint(pair[1]))
+	if errA != nil || errB != nil {
+		return 0, 0, false, fmt.Errorf("redis backend: malformed pair %v", pair)
+	}
+	return idA, idB, true, nil
+}
+
+func (b *RedisBackend) Len(ctx context.Context, bracket string) (int, error) {
+	n, err := b.client.ZCard(ctx, b.queueKey(bracket)).Result()
+	return int(n), err
+}
+
+// RegisterLiveness writes shardID into the shared "matchmakers" hash with
+// the current time, so operators can see which matchmaker replicas are
+// actively polling.
+func (b *RedisBackend) RegisterLiveness(ctx context.Context, shardID string, now time.Time) error {
+	return b.client.HSet(ctx, "matchmakers", shardID, now.Unix()).Err()
+}
@@ -0,0 +1,118 @@
+package matching
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisBackend(t *testing.T) *RedisBackend {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	return NewRedisBackend(client)
+}
+
+func TestRedisBackendPopsWithinAllowedDiff(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestRedisBackend(t)
+
+	backend.Enqueue(ctx, "1000-1200", 1, 1100)
+	backend.Enqueue(ctx, "1000-1200", 2, 1150)
+
+	userA, userB, ok, err := backend.PopPair(ctx, "1000-1200", 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a pair to be popped")
+	}
+	if userA != 1 || userB != 2 {
+		t.Fatalf("got pair (%d, %d), want (1, 2)", userA, userB)
+	}
+
+	if n, err := backend.Len(ctx, "1000-1200"); err != nil || n != 0 {
+		t.Fatalf("Len = (%d, %v), want (0, nil) after popping the only pair", n, err)
+	}
+}
+
+func TestRedisBackendSkipsPairsOutsideAllowedDiff(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestRedisBackend(t)
+
+	backend.Enqueue(ctx, "1000-1200", 1, 1000)
+	backend.Enqueue(ctx, "1000-1200", 2, 1200)
+
+	_, _, ok, err := backend.PopPair(ctx, "1000-1200", 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected no pair within the allowed diff")
+	}
+
+	if n, err := backend.Len(ctx, "1000-1200"); err != nil || n != 2 {
+		t.Fatalf("Len = (%d, %v), want (2, nil): nothing should be popped", n, err)
+	}
+}
+
+func TestRedisBackendGrowAllowedDiffAccumulates(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestRedisBackend(t)
+
+	if diff, err := backend.GrowAllowedDiff(ctx, "1000-1200", 1, 20); err != nil || diff != 20 {
+		t.Fatalf("GrowAllowedDiff = (%v, %v), want (20, nil)", diff, err)
+	}
+
+	time.Sleep(tickInterval + 100*time.Millisecond)
+
+	if diff, err := backend.GrowAllowedDiff(ctx, "1000-1200", 1, 20); err != nil || diff != 40 {
+		t.Fatalf("GrowAllowedDiff = (%v, %v), want (40, nil) a tick interval later", diff, err)
+	}
+}
+
+// TestRedisBackendGrowAllowedDiffRateLimitsConcurrentReplicas reproduces
+// what N matchmaker replicas sharing one RedisBackend do every tick:
+// they all call GrowAllowedDiff for the same front user at roughly the
+// same instant. Without rate-limiting, the search window would widen N
+// times as fast as a single instance; it should instead grow only once
+// per tick no matter how many replicas call it.
+func TestRedisBackendGrowAllowedDiffRateLimitsConcurrentReplicas(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestRedisBackend(t)
+
+	const replicas = 5
+	var wg sync.WaitGroup
+	wg.Add(replicas)
+	for i := 0; i < replicas; i++ {
+		go func() {
+			defer wg.Done()
+			backend.GrowAllowedDiff(ctx, "1000-1200", 1, 20)
+		}()
+	}
+	wg.Wait()
+
+	diff, err := backend.GrowAllowedDiff(ctx, "1000-1200", 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != 20 {
+		t.Fatalf("allowedDiff = %v after %d concurrent replica ticks, want 20 (grown exactly once)", diff, replicas)
+	}
+}
+
+func TestRedisBackendRegisterLiveness(t *testing.T) {
+	backend := newTestRedisBackend(t)
+	if err := backend.RegisterLiveness(context.Background(), "shard-1", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import "gorm.io/gorm"
+
+// DeckCard records how many copies of a Card a Deck runs. A plain
+// many2many association can't carry a quantity, so Deck.Cards goes
+// through this explicit join entity instead.
+type DeckCard struct {
+	ID       int `gorm:"primaryKey"`
+	DeckID   int `gorm:"index"`
+	CardID   int `gorm:"index"`
+	Card     Card
+	Quantity int
+}
+
+// DeckValidator, if set, is consulted by Deck's GORM hooks before a deck is
+// created or updated, so an invalid deck is rejected before it reaches the
+// database. It is wired up by the cards package's init, the same
+// inversion-of-control System uses for pluggable rating systems.
+var DeckValidator func(*Deck) error
+
+// BeforeCreate implements the GORM hook; see DeckValidator.
+func (deck *Deck) BeforeCreate(tx *gorm.DB) error {
+	return deck.validate()
+}
+
+// BeforeUpdate implements the GORM hook; see DeckValidator.
+func (deck *Deck) BeforeUpdate(tx *gorm.DB) error {
+	return deck.validate()
+}
+
+func (deck *Deck) validate() error {
+	if DeckValidator == nil {
+		return nil
+	}
+	return DeckValidator(deck)
+}
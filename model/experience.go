@@ -0,0 +1,125 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HeroExperience tracks a hero's cumulative XP. It is kept separate from
+// Hero itself so a hero's full XP history survives independently of its
+// Level/CurPt fields.
+type HeroExperience struct {
+	ID          int `gorm:"primaryKey"`
+	HeroID      int `gorm:"uniqueIndex"`
+	TotalXP     int
+	LastMatchXP int
+}
+
+const (
+	baseMatchXP      = 20  // awarded to every participant, win or lose
+	winBonusXP       = 10  // extra XP for the winner
+	opponentEloBonus = 200 // opponent Elo per extra XP point awarded
+	unlockLevel      = 2   // level at which a hero auto-unlocks
+
+	addXPMaxRetries   = 5
+	addXPRetryBackoff = 10 * time.Millisecond
+)
+
+// LevelUpEvent is published on LevelUps whenever AddXP crosses a level
+// threshold, so the GraphQL/HTTP layer can relay level-ups to clients.
+type LevelUpEvent struct {
+	HeroID   int
+	NewLevel int
+}
+
+// LevelUps is a broadcast channel of LevelUpEvent. It is buffered so AddXP
+// never blocks on a slow or absent subscriber; a subscriber that falls
+// behind simply misses events rather than stalling match reporting.
+var LevelUps = make(chan LevelUpEvent, 16)
+
+// LevelTable returns the cumulative XP required to reach level n.
+func LevelTable(level int) int {
+	return int(math.Floor(100 * math.Pow(float64(level), 1.5)))
+}
+
+// RequiredXPForNextLevel returns the cumulative XP hero needs to reach its
+// next level, per LevelTable.
+func (hero *Hero) RequiredXPForNextLevel() int {
+	return LevelTable(hero.Level + 1)
+}
+
+// MatchXP computes the XP a hero earns for a single reported match: a flat
+// base, a win bonus, plus a bonus scaled by the opponent's Elo so wins
+// against stronger opponents are worth more.
+func MatchXP(opponentElo int, result int) int {
+	xp := baseMatchXP + opponentElo/opponentEloBonus
+	if result == ELO_RESULT_WIN {
+		xp += winBonusXP
+	}
+	return xp
+}
+
+// AddXP awards amount XP to hero's persisted HeroExperience, leveling the
+// hero up (possibly several times) whenever its cumulative XP crosses the
+// next threshold, and unlocking it automatically at unlockLevel. Each
+// attempt re-reads the hero fresh from inside its own transaction and
+// only updates hero's fields once that attempt actually commits, so a
+// transaction that fails part-way through (e.g. SQLite lock contention
+// from another concurrent AddXP) and is retried never starts from a
+// level-up the failed attempt applied in memory but never persisted.
+func (hero *Hero) AddXP(amount int) error {
+	var lastErr error
+	for attempt := 0; attempt <= addXPMaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(addXPRetryBackoff * time.Duration(int(1)<<uint(attempt-1)))
+		}
+
+		var fresh Hero
+		leveledUp := false
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.First(&fresh, hero.ID).Error; err != nil {
+				return err
+			}
+
+			var xp HeroExperience
+			tx.Where(HeroExperience{HeroID: fresh.ID}).FirstOrCreate(&xp)
+
+			xp.TotalXP += amount
+			xp.LastMatchXP = amount
+
+			for xp.TotalXP >= LevelTable(fresh.Level+1) {
+				fresh.Level++
+				leveledUp = true
+				if fresh.Level == unlockLevel {
+					fresh.IsUnlocked = true
+				}
+			}
+
+			if err := tx.Save(&xp).Error; err != nil {
+				return err
+			}
+			if leveledUp {
+				if err := tx.Save(&fresh).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			hero.Level = fresh.Level
+			hero.IsUnlocked = fresh.IsUnlocked
+			if leveledUp {
+				select {
+				case LevelUps <- LevelUpEvent{HeroID: hero.ID, NewLevel: hero.Level}:
+				default:
+				}
+			}
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("add xp to hero %d: all %d attempts failed: %w", hero.ID, addXPMaxRetries+1, lastErr)
+}
@@ -0,0 +1,62 @@
+package model
+
+import "fmt"
+
+// Match is a pending or completed pairing produced by the matchmaker. The
+// Elo update for a match is only applied once ReportMatchResult commits a
+// result, so that clients control when rating changes actually land.
+type Match struct {
+	ID        int    `gorm:"primaryKey"`
+	User1ID   int    `gorm:"index"`
+	User2ID   int    `gorm:"index"`
+	RoomToken string `gorm:"unique;not null"`
+	Result    int
+	Reported  bool
+}
+
+// ReportMatchResult commits the outcome of an in-flight match and applies
+// the corresponding Elo update to both participants exactly once. result is
+// interpreted from User1's perspective (ELO_RESULT_WIN/LOSS/TIE).
+func ReportMatchResult(matchID int, result int) (*Match, error) {
+	if result != ELO_RESULT_WIN && result != ELO_RESULT_LOSS && result != ELO_RESULT_TIE {
+		return nil, fmt.Errorf("match %d: invalid result %d, must be one of ELO_RESULT_WIN/LOSS/TIE", matchID, result)
+	}
+
+	var match Match
+	if err := DB.First(&match, matchID).Error; err != nil {
+		return nil, err
+	}
+	if match.Reported {
+		return nil, fmt.Errorf("match %d already reported", matchID)
+	}
+
+	var user1, user2 User
+	DB.First(&user1, match.User1ID)
+	DB.First(&user2, match.User2ID)
+
+	var hero1, hero2 Hero
+	DB.First(&hero1, user1.CurHeroID)
+	DB.First(&hero2, user2.CurHeroID)
+
+	user1.ApplyMatchResult(hero2, result)
+	user2.ApplyMatchResult(hero1, -result)
+
+	// XP is awarded against each opponent's pre-match Elo, same as the
+	// rating update above, and only after that update has committed. A
+	// failure here shouldn't fail the whole match report, so it's logged
+	// rather than returned, same as CreateUser's rating-provider fallback.
+	var updatedHero1, updatedHero2 Hero
+	DB.First(&updatedHero1, user1.CurHeroID)
+	DB.First(&updatedHero2, user2.CurHeroID)
+	if err := updatedHero1.AddXP(MatchXP(hero2.CurPt, result)); err != nil {
+		fmt.Printf("match %d: awarding XP to hero %d failed: %v\n", matchID, updatedHero1.ID, err)
+	}
+	if err := updatedHero2.AddXP(MatchXP(hero1.CurPt, -result)); err != nil {
+		fmt.Printf("match %d: awarding XP to hero %d failed: %v\n", matchID, updatedHero2.ID, err)
+	}
+
+	match.Result = result
+	match.Reported = true
+	DB.Save(&match)
+	return &match, nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import "testing"
+
+func TestReportMatchResultRejectsInvalidResult(t *testing.T) {
+	DB = newTestDB(t)
+	if err := DB.AutoMigrate(&User{}, &Match{}); err != nil {
+		t.Fatalf("migrate test db: %v", err)
+	}
+
+	user1 := User{Name: "p1"}
+	user2 := User{Name: "p2"}
+	DB.Create(&user1)
+	DB.Create(&user2)
+	match := Match{User1ID: user1.ID, User2ID: user2.ID, RoomToken: "token"}
+	DB.Create(&match)
+
+	if _, err := ReportMatchResult(match.ID, 42); err == nil {
+		t.Fatal("expected an error for a result outside ELO_RESULT_WIN/LOSS/TIE")
+	}
+
+	var persisted Match
+	DB.First(&persisted, match.ID)
+	if persisted.Reported {
+		t.Fatal("an invalid result must not mark the match as reported")
+	}
+}
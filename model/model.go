@@ -0,0 +1,164 @@
+// Package model holds the GORM-backed domain types shared by the matching
+// engine, the GraphQL layer, and the CLI entry point.
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/provider"
+	"github.com/NarwhalChen/Hearthstone-ELO-Matching-System/rating"
+)
+
+// Constants definition
+const (
+	ELO_RESULT_WIN     = 1
+	ELO_RESULT_LOSS    = -1
+	ELO_RESULT_TIE     = 0
+	ELO_RATING_DEFAULT = 1500
+	DECREASE_RATE      = 20
+)
+
+// Struct definitions with GORM tags
+type Card struct {
+	ID     int    `gorm:"primaryKey"`
+	Name   string `gorm:"unique;not null"`
+	Cost   int
+	Class  string // hero class that may play this card, or "Neutral"
+	Rarity string // e.g. "Common", "Rare", "Epic", "Legendary"
+	Set    string
+}
+
+type Deck struct {
+	ID         int        `gorm:"primaryKey"`
+	Name       string     `gorm:"unique;not null"`
+	HeroID     int        `gorm:"index"` // the hero this deck is built for
+	Cards      []DeckCard `gorm:"foreignKey:DeckID"`
+	CurWin     int
+	CurLose    int
+	CurWinRate float32
+}
+
+type Hero struct {
+	ID         int    `gorm:"primaryKey"`
+	UserID     int    `gorm:"index"` // For linking with User
+	Name       string `gorm:"not null"`
+	IsUnlocked bool
+	Level      int
+	CurWin     int
+	CurLose    int
+	CurWinRate float32
+	CurPt      int // legacy Elo-scale rating, kept in sync with Rating for display/sort
+	Rating     float64
+	RD         float64
+	Volatility float64
+	Decks      []Deck `gorm:"foreignKey:HeroID"`
+}
+
+type User struct {
+	ID          int `gorm:"primaryKey"`
+	IsOnline    bool
+	Name        string `gorm:"unique;not null"`
+	CurHeroID   int
+	AllowedDiff int
+	HeroList    []Hero `gorm:"foreignKey:UserID"` // One-to-many relationship between User and Hero
+}
+
+// DB is the shared GORM handle, set once by main during startup.
+var DB *gorm.DB
+
+// CreateUser creates a new user and initializes their hero list. When
+// externalID is non-empty and ratingProvider is non-nil, the hero list is
+// seeded from ratingProvider.FetchElo instead of ELO_RATING_DEFAULT; a
+// provider error is logged and falls back to the default rating rather
+// than failing user creation.
+func CreateUser(name string, externalID string, ratingProvider provider.RatingProvider) User {
+	user := User{
+		Name:        name,
+		IsOnline:    true,
+		AllowedDiff: 0,
+	}
+	DB.Create(&user) // Save user to the database
+
+	// Create default hero list for the user
+	heroes := CreateDefaultHeroes(user.ID)
+	if externalID != "" && ratingProvider != nil {
+		if elo, err := ratingProvider.FetchElo(context.Background(), externalID); err != nil {
+			fmt.Printf("rating provider lookup for %q failed, using default rating: %v\n", externalID, err)
+		} else {
+			for i := range heroes {
+				heroes[i].CurPt = elo
+				heroes[i].Rating = float64(elo)
+			}
+		}
+	}
+	for _, hero := range heroes {
+		DB.Create(&hero) // Save each hero to the database
+	}
+	return user
+}
+
+// CreateDefaultHeroes creates a default hero list for the user, with
+// ratings at Glicko-2's recommended defaults (RD 350, Volatility 0.06) so
+// new heroes are equally valid under either rating System.
+func CreateDefaultHeroes(userID int) []Hero {
+	defaultHero := func(name string) Hero {
+		return Hero{
+			UserID: userID, Name: name, IsUnlocked: false, Level: 1,
+			CurWin: 0, CurLose: 0, CurWinRate: 0.0, CurPt: ELO_RATING_DEFAULT,
+			Rating: ELO_RATING_DEFAULT, RD: rating.GlickoRDDefault, Volatility: rating.GlickoVolatilityDefault,
+		}
+	}
+	return []Hero{
+		defaultHero("Druid"),
+		defaultHero("Hunter"),
+		defaultHero("Mage"),
+		defaultHero("Paladin"),
+		defaultHero("Priest"),
+		defaultHero("Rogue"),
+		defaultHero("Shaman"),
+		defaultHero("Warlock"),
+		defaultHero("Warrior"),
+		defaultHero("Demon Hunter"),
+	}
+}
+
+// PrintUserHeroes prints the user's hero list.
+func PrintUserHeroes(user User) {
+	var heroes []Hero
+	DB.Where("user_id = ?", user.ID).Find(&heroes)
+	fmt.Printf("User: %s's Heroes:\n", user.Name)
+	for _, hero := range heroes {
+		fmt.Printf("Hero: %s, Level: %d, IsUnlocked: %v, Elo: %d\n", hero.Name, hero.Level, hero.IsUnlocked, hero.CurPt)
+	}
+}
+
+// GetCurHeroPt gets the current hero's Elo score for a user.
+func (user *User) GetCurHeroPt() int {
+	var hero Hero
+	DB.First(&hero, user.CurHeroID)
+	if hero.ID > 0 {
+		return hero.CurPt
+	}
+	return ELO_RATING_DEFAULT // Return default Elo score if no hero is found
+}
+
+// UpdateCurHeroPt updates the current hero's Elo score for a user.
+func (user *User) UpdateCurHeroPt(newPt int) {
+	var hero Hero
+	DB.First(&hero, user.CurHeroID)
+	if hero.ID > 0 {
+		hero.CurPt = newPt
+		DB.Save(&hero)
+	}
+}
+
+// LeaderboardByHero returns the top heroes with the given name ordered by
+// current Elo, highest first.
+func LeaderboardByHero(heroName string, limit int) ([]Hero, error) {
+	var heroes []Hero
+	err := DB.Where("name = ?", heroName).Order("cur_pt desc").Limit(limit).Find(&heroes).Error
+	return heroes, err
+}
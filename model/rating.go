@@ -0,0 +1,63 @@
+package model
+
+import "github.com/NarwhalChen/Hearthstone-ELO-Matching-System/rating"
+
+// System is the active rating system. ApplyMatchResult and SearchWindow
+// both defer to it, so operators can switch the whole matchmaker between
+// Elo and Glicko-2 by assigning System at startup instead of editing call
+// sites.
+var System rating.RatingSystem = rating.Elo{}
+
+func (hero *Hero) state() rating.State {
+	return rating.State{Rating: hero.Rating, RD: hero.RD, Volatility: hero.Volatility}
+}
+
+func (hero *Hero) applyState(state rating.State) {
+	hero.Rating = state.Rating
+	hero.RD = state.RD
+	hero.Volatility = state.Volatility
+	hero.CurPt = int(state.Rating)
+}
+
+// ApplyMatchResult updates curUser's current hero's rating against
+// opponent's current hero using the active rating System, then persists
+// it. It replaces the old direct per-game EloCal update, which only ever
+// understood a plain Elo rating.
+func (curUser *User) ApplyMatchResult(opponent Hero, result int) {
+	var hero Hero
+	DB.First(&hero, curUser.CurHeroID)
+	if hero.ID == 0 {
+		return
+	}
+
+	var score float64
+	switch result {
+	case ELO_RESULT_WIN:
+		score = 1.0
+	case ELO_RESULT_TIE:
+		score = 0.5
+	case ELO_RESULT_LOSS:
+		score = 0.0
+	default:
+		return
+	}
+
+	next := System.Update(hero.state(), []rating.Outcome{
+		{OpponentRating: opponent.Rating, OpponentRD: opponent.RD, Score: score},
+	})
+	hero.applyState(next)
+	DB.Save(&hero)
+}
+
+// SearchWindow returns how wide a window the matchmaker should search
+// around user's current hero, per the active rating System. For Elo this
+// reproduces the original 28800/elo heuristic; for Glicko-2 it grows with
+// RD instead.
+func (user *User) SearchWindow() float64 {
+	var hero Hero
+	DB.First(&hero, user.CurHeroID)
+	if hero.ID == 0 {
+		return 0
+	}
+	return System.SearchWindow(hero.state())
+}
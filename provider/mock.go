@@ -0,0 +1,19 @@
+package provider
+
+import "context"
+
+// MockProvider returns a fixed Elo (or Err, if set) for every externalID.
+// It exists so callers can exercise the seeding path in tests without
+// making a real HTTP call.
+type MockProvider struct {
+	Elo int
+	Err error
+}
+
+// FetchElo implements RatingProvider.
+func (m *MockProvider) FetchElo(ctx context.Context, externalID string) (int, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	return m.Elo, nil
+}
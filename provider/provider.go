@@ -0,0 +1,100 @@
+// Package provider seeds a hero's initial Elo from an external rating
+// source (e.g. a Battle.net tag or a ranked-ladder handle) instead of
+// always starting new heroes at model.ELO_RATING_DEFAULT.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RatingProvider resolves an external identifier to a seed Elo rating.
+type RatingProvider interface {
+	FetchElo(ctx context.Context, externalID string) (int, error)
+}
+
+// ProviderConfig configures an HTTPProvider. Zero values for MaxRetries,
+// Backoff, and Timeout fall back to sane defaults.
+type ProviderConfig struct {
+	BaseURL    string // e.g. "https://ratings.example.com/v1/players"
+	MaxRetries int
+	Backoff    time.Duration
+	Timeout    time.Duration
+}
+
+// HTTPProvider fetches a rating by issuing `GET {BaseURL}/{externalID}` and
+// decoding a `{"elo": int}` response, retrying transient failures with
+// exponential backoff.
+type HTTPProvider struct {
+	cfg    ProviderConfig
+	client *http.Client
+}
+
+// NewHTTPProvider builds an HTTPProvider, applying default retry/timeout
+// settings where cfg leaves them unset.
+func NewHTTPProvider(cfg ProviderConfig) *HTTPProvider {
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = 200 * time.Millisecond
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	return &HTTPProvider{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+type eloResponse struct {
+	Elo int `json:"elo"`
+}
+
+// FetchElo implements RatingProvider.
+func (p *HTTPProvider) FetchElo(ctx context.Context, externalID string) (int, error) {
+	url := fmt.Sprintf("%s/%s", p.cfg.BaseURL, externalID)
+
+	var lastErr error
+	for attempt := 0; attempt <= p.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			wait := p.cfg.Backoff * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		elo, err := p.fetchOnce(ctx, url)
+		if err == nil {
+			return elo, nil
+		}
+		lastErr = err
+	}
+	return 0, fmt.Errorf("rating provider: all %d attempts failed: %w", p.cfg.MaxRetries+1, lastErr)
+}
+
+func (p *HTTPProvider) fetchOnce(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("rating provider returned status %d", resp.StatusCode)
+	}
+
+	var parsed eloResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return 0, err
+	}
+	return parsed.Elo, nil
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMockProviderReturnsFixedElo(t *testing.T) {
+	m := &MockProvider{Elo: 1800}
+	elo, err := m.FetchElo(context.Background(), "player#1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elo != 1800 {
+		t.Fatalf("got elo %d, want 1800", elo)
+	}
+}
+
+func TestHTTPProviderFetchesElo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(eloResponse{Elo: 2100})
+	}))
+	defer srv.Close()
+
+	p := NewHTTPProvider(ProviderConfig{BaseURL: srv.URL, Backoff: time.Millisecond})
+	elo, err := p.FetchElo(context.Background(), "player#1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elo != 2100 {
+		t.Fatalf("got elo %d, want 2100", elo)
+	}
+}
+
+func TestHTTPProviderRetriesThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(eloResponse{Elo: 1700})
+	}))
+	defer srv.Close()
+
+	p := NewHTTPProvider(ProviderConfig{BaseURL: srv.URL, MaxRetries: 3, Backoff: time.Millisecond})
+	elo, err := p.FetchElo(context.Background(), "player#1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elo != 1700 {
+		t.Fatalf("got elo %d, want 1700", elo)
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestHTTPProviderExhaustsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	p := NewHTTPProvider(ProviderConfig{BaseURL: srv.URL, MaxRetries: 2, Backoff: time.Millisecond})
+	if _, err := p.FetchElo(context.Background(), "player#1234"); err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+}
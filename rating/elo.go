@@ -0,0 +1,39 @@
+package rating
+
+import "math"
+
+// Elo is the classic per-game Elo rating system that previously lived on
+// User.eloCal/computeK. Update applies each outcome in sequence, exactly
+// as the old immediate per-game update did.
+type Elo struct{}
+
+// Update implements RatingSystem.
+func (Elo) Update(state State, outcomes []Outcome) State {
+	r := state.Rating
+	for _, o := range outcomes {
+		k := computeK(r)
+		expected := 1.0 / (1.0 + math.Pow(10, (o.OpponentRating-r)/400))
+		r += k * (o.Score - expected)
+	}
+	return State{Rating: r}
+}
+
+// SearchWindow implements RatingSystem, preserving the matchmaker's
+// original 28800/elo heuristic.
+func (Elo) SearchWindow(state State) float64 {
+	if state.Rating == 0 {
+		return 0
+	}
+	return 28800.0 / state.Rating
+}
+
+func computeK(rating float64) float64 {
+	switch {
+	case rating >= 2400:
+		return 16
+	case rating >= 2100:
+		return 24
+	default:
+		return 36
+	}
+}
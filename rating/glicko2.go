@@ -0,0 +1,122 @@
+package rating
+
+import "math"
+
+// Defaults for a hero that has never played a rated game.
+const (
+	GlickoRDDefault         = 350.0
+	GlickoVolatilityDefault = 0.06
+)
+
+const (
+	glickoScale       = 173.7178 // converts between Glicko and Glicko-2 scales
+	glickoTau         = 0.5      // constrains volatility change between periods
+	glickoConvergence = 1e-6     // Illinois algorithm stopping tolerance
+)
+
+// Glicko2 implements the Glicko-2 rating system (Glickman, "Example of the
+// Glicko-2 system"), tracking rating, rating deviation (RD), and
+// volatility per hero.
+type Glicko2 struct{}
+
+// Update implements RatingSystem. A hero with no games in the period only
+// decays RD, per step 6 of the Glicko-2 spec.
+func (Glicko2) Update(state State, outcomes []Outcome) State {
+	rd := state.RD
+	if rd <= 0 {
+		rd = GlickoRDDefault
+	}
+	sigma := state.Volatility
+	if sigma <= 0 {
+		sigma = GlickoVolatilityDefault
+	}
+	mu := (state.Rating - 1500) / glickoScale
+	phi := rd / glickoScale
+
+	if len(outcomes) == 0 {
+		newPhi := math.Sqrt(phi*phi + sigma*sigma)
+		return State{Rating: state.Rating, RD: newPhi * glickoScale, Volatility: sigma}
+	}
+
+	var v, deltaSum float64
+	for _, o := range outcomes {
+		muJ := (o.OpponentRating - 1500) / glickoScale
+		phiJ := o.OpponentRD / glickoScale
+		gj := g(phiJ)
+		ej := e(mu, muJ, gj)
+		v += gj * gj * ej * (1 - ej)
+		deltaSum += gj * (o.Score - ej)
+	}
+	v = 1 / v
+	delta := v * deltaSum
+
+	newSigma := newVolatility(delta, phi, v, sigma)
+
+	phiStar := math.Sqrt(phi*phi + newSigma*newSigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	return State{
+		Rating:     newMu*glickoScale + 1500,
+		RD:         newPhi * glickoScale,
+		Volatility: newSigma,
+	}
+}
+
+// SearchWindow implements RatingSystem, sizing the matchmaker's window on
+// RD: a less certain rating searches a wider band of opponents.
+func (Glicko2) SearchWindow(state State) float64 {
+	rd := state.RD
+	if rd <= 0 {
+		rd = GlickoRDDefault
+	}
+	return 2 * rd
+}
+
+// g is g(RD) = 1/sqrt(1 + 3*RD^2/pi^2).
+func g(rd float64) float64 {
+	return 1 / math.Sqrt(1+3*rd*rd/(math.Pi*math.Pi))
+}
+
+// e is the expected score E = 1/(1 + exp(-g(RDj)*(mu - muJ))).
+func e(mu, muJ, gRDj float64) float64 {
+	return 1 / (1 + math.Exp(-gRDj*(mu-muJ)))
+}
+
+// newVolatility solves for sigma' by iterating the Illinois algorithm on
+// f(x) per step 5 of the Glicko-2 spec.
+func newVolatility(delta, phi, v, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * math.Pow(phi*phi+v+ex, 2)
+		return num/den - (x-a)/(glickoTau*glickoTau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		B = a - k*glickoTau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoConvergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB < 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
@@ -0,0 +1,49 @@
+package rating
+
+import "testing"
+
+// TestGlicko2MatchesSpecExample reproduces the worked example from
+// Glickman's "Example of the Glicko-2 system", where a player rated
+// 1500/200/0.06 plays three games in one period.
+func TestGlicko2MatchesSpecExample(t *testing.T) {
+	g := Glicko2{}
+	start := State{Rating: 1500, RD: 200, Volatility: 0.06}
+
+	got := g.Update(start, []Outcome{
+		{OpponentRating: 1400, OpponentRD: 30, Score: 1},
+		{OpponentRating: 1550, OpponentRD: 100, Score: 0},
+		{OpponentRating: 1700, OpponentRD: 300, Score: 0},
+	})
+
+	if diff := got.Rating - 1464.06; diff < -0.5 || diff > 0.5 {
+		t.Errorf("rating = %v, want ~1464.06", got.Rating)
+	}
+	if diff := got.RD - 151.52; diff < -0.5 || diff > 0.5 {
+		t.Errorf("RD = %v, want ~151.52", got.RD)
+	}
+	if diff := got.Volatility - 0.05999; diff < -0.0005 || diff > 0.0005 {
+		t.Errorf("volatility = %v, want ~0.05999", got.Volatility)
+	}
+}
+
+func TestGlicko2DecaysRDWithNoGames(t *testing.T) {
+	g := Glicko2{}
+	start := State{Rating: 1500, RD: 200, Volatility: 0.06}
+
+	got := g.Update(start, nil)
+
+	if got.Rating != 1500 {
+		t.Errorf("rating changed with no games: got %v", got.Rating)
+	}
+	if got.RD <= 200 {
+		t.Errorf("RD should grow when a hero sits out a period: got %v", got.RD)
+	}
+}
+
+func TestEloSearchWindowMatchesOriginalHeuristic(t *testing.T) {
+	e := Elo{}
+	window := e.SearchWindow(State{Rating: 1440})
+	if want := 28800.0 / 1440.0; window != want {
+		t.Errorf("SearchWindow = %v, want %v", window, want)
+	}
+}
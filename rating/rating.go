@@ -0,0 +1,28 @@
+// Package rating implements pluggable rating systems — classic Elo and
+// Glicko-2 — behind a common RatingSystem interface, so gameRoom and the
+// matchmaker can be switched between them by configuration rather than by
+// editing call sites.
+package rating
+
+// Outcome is a single game result from the subject's perspective.
+type Outcome struct {
+	OpponentRating float64
+	OpponentRD     float64 // unused by Elo
+	Score          float64 // 1 = win, 0.5 = tie, 0 = loss
+}
+
+// State is a rating system's per-hero state. Elo only ever sets Rating;
+// Glicko-2 also maintains RD and Volatility.
+type State struct {
+	Rating     float64
+	RD         float64
+	Volatility float64
+}
+
+// RatingSystem updates a player's rating state after a rating period's
+// games (an empty outcomes slice means the hero sat out the period) and
+// sizes the matchmaker's opponent search window for a given state.
+type RatingSystem interface {
+	Update(state State, outcomes []Outcome) State
+	SearchWindow(state State) float64
+}